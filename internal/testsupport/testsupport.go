@@ -0,0 +1,14 @@
+// Package testsupport holds small fixtures shared by this module's tests
+// across package boundaries (e.g. pkg/storage/memory and pkg/service/v1).
+package testsupport
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// Reminder returns a valid Reminder timestamp for ToDo test fixtures; every
+// storage.ToDoRepository implementation rejects a nil Reminder.
+func Reminder() *tspb.Timestamp {
+	return ptypes.TimestampNow()
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/healthcheck"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/logger"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/protocol/grpc"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/protocol/rest"
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/service/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage/memory"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage/mysql"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage/postgres"
+)
+
+// Config is configuration for the server, filled from command line flags
+type Config struct {
+	// gRPC server start parameters section
+	// GRPCPort is the TCP port to listen to for gRPC clients
+	GRPCPort string
+
+	// HTTP/REST gateway start parameters section
+	// HTTPPort is the TCP port to listen to for HTTP/REST clients, front of gRPC
+	HTTPPort string
+
+	// DB datastore parameters section
+	// DatastoreBackend selects the storage.ToDoRepository implementation:
+	// "mysql" (default), "postgres", or "memory" (no database required)
+	DatastoreBackend string
+	// DatastoreDBHost is host of database
+	DatastoreDBHost string
+	// DatastoreDBUser is username to connect to database
+	DatastoreDBUser string
+	// DatastoreDBPassword password to connect to database
+	DatastoreDBPassword string
+	// DatastoreDBSchema is schema of database
+	DatastoreDBSchema string
+
+	// LogLevel is the zap log level (debug, info, warn, error) for the
+	// structured request logs emitted by the gRPC and HTTP/REST middleware
+	LogLevel string
+}
+
+// RunServer runs gRPC and HTTP/REST gateway servers side by side. A
+// SIGINT/SIGTERM cancels the shared context so both servers shut down
+// together instead of one lingering after the other stops.
+func RunServer() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var cfg Config
+	flag.StringVar(&cfg.GRPCPort, "grpc-port", "", "gRPC port to bind")
+	flag.StringVar(&cfg.HTTPPort, "http-port", "", "HTTP port to bind")
+	flag.StringVar(&cfg.DatastoreBackend, "db-backend", "mysql", "Storage backend: mysql, postgres, or memory")
+	flag.StringVar(&cfg.DatastoreDBHost, "db-host", "", "Database host")
+	flag.StringVar(&cfg.DatastoreDBUser, "db-user", "", "Database user")
+	flag.StringVar(&cfg.DatastoreDBPassword, "db-password", "", "Database password")
+	flag.StringVar(&cfg.DatastoreDBSchema, "db-schema", "", "Database schema")
+	flag.StringVar(&cfg.LogLevel, "log-level", "info", "Log level for request logs (debug, info, warn, error)")
+	flag.Parse()
+
+	if err := logger.Init(cfg.LogLevel); err != nil {
+		return fmt.Errorf("failed to initialize logger: %v", err)
+	}
+
+	if len(cfg.GRPCPort) == 0 {
+		return fmt.Errorf("invalid TCP port for gRPC server: '%s'", cfg.GRPCPort)
+	}
+	if len(cfg.HTTPPort) == 0 {
+		return fmt.Errorf("invalid TCP port for HTTP gateway: '%s'", cfg.HTTPPort)
+	}
+
+	repo, closeRepo, err := newRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	v1API := v1.NewToDoServiceServer(repo)
+	checker := healthcheck.New()
+
+	group, gCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return grpc.RunServer(gCtx, v1API, repo, checker, cfg.GRPCPort)
+	})
+	group.Go(func() error {
+		return rest.RunServer(gCtx, cfg.GRPCPort, cfg.HTTPPort, checker)
+	})
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	group.Go(func() error {
+		select {
+		case v := <-c:
+			log.Printf("shutting down, got signal: %v", v)
+			cancel()
+		case <-gCtx.Done():
+		}
+		return nil
+	})
+
+	return group.Wait()
+}
+
+// newRepository builds the storage.ToDoRepository selected by
+// cfg.DatastoreBackend. The returned close func must be called once the
+// repository is no longer needed.
+func newRepository(cfg Config) (storage.ToDoRepository, func(), error) {
+	noop := func() {}
+
+	switch cfg.DatastoreBackend {
+	case "memory":
+		return memory.NewRepository(), noop, nil
+
+	case "postgres":
+		param := fmt.Sprintf("host=%s user=%s password=%s dbname=%s sslmode=disable",
+			cfg.DatastoreDBHost, cfg.DatastoreDBUser, cfg.DatastoreDBPassword, cfg.DatastoreDBSchema)
+		db, err := sql.Open("postgres", param)
+		if err != nil {
+			return nil, noop, fmt.Errorf("failed to open database connection: %v", err)
+		}
+		return postgres.NewRepository(db), func() { db.Close() }, nil
+
+	case "mysql":
+		param := fmt.Sprintf("%s:%s@tcp(%s)/%s", cfg.DatastoreDBUser, cfg.DatastoreDBPassword, cfg.DatastoreDBHost, cfg.DatastoreDBSchema)
+		db, err := sql.Open("mysql", param)
+		if err != nil {
+			return nil, noop, fmt.Errorf("failed to open database connection: %v", err)
+		}
+		return mysql.NewRepository(db), func() { db.Close() }, nil
+
+	default:
+		return nil, noop, fmt.Errorf("unsupported storage backend: '%s'", cfg.DatastoreBackend)
+	}
+}
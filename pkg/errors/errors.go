@@ -0,0 +1,101 @@
+// Package errors defines the typed domain errors returned by the service
+// layer. They carry enough information (a Code, a message, and an optional
+// wrapped cause) for the gRPC and HTTP/REST transports to translate them
+// into the right status/code without the service layer having to know
+// anything about gRPC or HTTP.
+package errors
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Code identifies the category of a domain error, independent of any
+// particular transport.
+type Code int
+
+const (
+	// CodeInternal is an unexpected failure not caused by the caller.
+	CodeInternal Code = iota
+	// CodeNotFound is returned when the requested entity does not exist.
+	CodeNotFound
+	// CodeAlreadyExists is returned when the entity being created already exists.
+	CodeAlreadyExists
+	// CodeValidation is returned when the request fails input validation.
+	CodeValidation
+	// CodeDeadlineExceeded is returned when an operation did not complete in time.
+	CodeDeadlineExceeded
+	// CodeUnauthenticated is returned when the caller's credentials are missing or invalid.
+	CodeUnauthenticated
+	// CodeConflict is returned when the request conflicts with the current state of the entity.
+	CodeConflict
+)
+
+// Error is a typed domain error returned by the service layer instead of a
+// gRPC status error, so storage/service code stays decoupled from
+// transport. Transport-level interceptors translate it into gRPC codes, and
+// the HTTP/REST gateway maps those codes to HTTP status codes.
+type Error struct {
+	Code    Code
+	Message string
+	Cause   error
+	// Stack holds a captured stack trace for CodeInternal errors, useful
+	// for debugging but never surfaced to the caller.
+	Stack []byte
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+// Unwrap allows errors.Is/errors.As to see through to Cause.
+func (e *Error) Unwrap() error { return e.Cause }
+
+func newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound reports that the requested entity does not exist.
+func NotFound(format string, args ...interface{}) *Error {
+	return newf(CodeNotFound, format, args...)
+}
+
+// AlreadyExists reports that the entity being created already exists.
+func AlreadyExists(format string, args ...interface{}) *Error {
+	return newf(CodeAlreadyExists, format, args...)
+}
+
+// Validation reports that the request failed input validation.
+func Validation(format string, args ...interface{}) *Error {
+	return newf(CodeValidation, format, args...)
+}
+
+// DeadlineExceeded reports that an operation did not complete in time.
+func DeadlineExceeded(format string, args ...interface{}) *Error {
+	return newf(CodeDeadlineExceeded, format, args...)
+}
+
+// Unauthenticated reports that the caller's credentials are missing or invalid.
+func Unauthenticated(format string, args ...interface{}) *Error {
+	return newf(CodeUnauthenticated, format, args...)
+}
+
+// Conflict reports that the request conflicts with the current state of the entity.
+func Conflict(format string, args ...interface{}) *Error {
+	return newf(CodeConflict, format, args...)
+}
+
+// Internal wraps cause as an internal error, capturing a stack trace at the
+// point of failure so it can be logged for debugging without leaking
+// implementation details to the caller.
+func Internal(cause error, format string, args ...interface{}) *Error {
+	return &Error{
+		Code:    CodeInternal,
+		Message: fmt.Sprintf(format, args...),
+		Cause:   cause,
+		Stack:   debug.Stack(),
+	}
+}
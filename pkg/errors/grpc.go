@@ -0,0 +1,33 @@
+package errors
+
+import (
+	stderrors "errors"
+
+	"google.golang.org/grpc/codes"
+)
+
+// GRPCCode maps err to the gRPC status code that should be returned to
+// clients. Errors that aren't a *Error (e.g. one already built with
+// status.Errorf) are treated as internal.
+func GRPCCode(err error) codes.Code {
+	var e *Error
+	if !stderrors.As(err, &e) {
+		return codes.Internal
+	}
+	switch e.Code {
+	case CodeNotFound:
+		return codes.NotFound
+	case CodeAlreadyExists:
+		return codes.AlreadyExists
+	case CodeValidation:
+		return codes.InvalidArgument
+	case CodeDeadlineExceeded:
+		return codes.DeadlineExceeded
+	case CodeUnauthenticated:
+		return codes.Unauthenticated
+	case CodeConflict:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
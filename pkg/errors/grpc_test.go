@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", NotFound("todo %d", 1), codes.NotFound},
+		{"already exists", AlreadyExists("todo %d", 1), codes.AlreadyExists},
+		{"validation", Validation("bad input"), codes.InvalidArgument},
+		{"deadline exceeded", DeadlineExceeded("timed out"), codes.DeadlineExceeded},
+		{"unauthenticated", Unauthenticated("no token"), codes.Unauthenticated},
+		{"conflict", Conflict("stale version"), codes.Aborted},
+		{"internal", Internal(errors.New("boom"), "failed"), codes.Internal},
+		{"unknown error type", errors.New("plain error"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GRPCCode(tt.err); got != tt.want {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// HTTPStatus maps err to the HTTP status code the REST gateway should
+// respond with. Errors that aren't a *Error are treated as internal.
+func HTTPStatus(err error) int {
+	var e *Error
+	if !stderrors.As(err, &e) {
+		return http.StatusInternalServerError
+	}
+	return HTTPStatusFromCode(GRPCCode(e))
+}
+
+// HTTPStatusFromCode maps a gRPC status code to the HTTP status code the
+// REST gateway should respond with. Used by the gateway's error handler,
+// which only sees the gRPC status produced by the error translation
+// interceptor, not the original *Error.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.Aborted:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", NotFound("todo %d", 1), http.StatusNotFound},
+		{"already exists", AlreadyExists("todo %d", 1), http.StatusConflict},
+		{"validation", Validation("bad input"), http.StatusBadRequest},
+		{"deadline exceeded", DeadlineExceeded("timed out"), http.StatusGatewayTimeout},
+		{"unauthenticated", Unauthenticated("no token"), http.StatusUnauthorized},
+		{"conflict", Conflict("stale version"), http.StatusConflict},
+		{"internal", Internal(errors.New("boom"), "failed"), http.StatusInternalServerError},
+		{"unknown error type", errors.New("plain error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatus(tt.err); got != tt.want {
+				t.Errorf("HTTPStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code codes.Code
+		want int
+	}{
+		{"not found", codes.NotFound, http.StatusNotFound},
+		{"already exists", codes.AlreadyExists, http.StatusConflict},
+		{"invalid argument", codes.InvalidArgument, http.StatusBadRequest},
+		{"deadline exceeded", codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"unauthenticated", codes.Unauthenticated, http.StatusUnauthorized},
+		{"aborted", codes.Aborted, http.StatusConflict},
+		{"internal", codes.Internal, http.StatusInternalServerError},
+		{"unmapped code", codes.Unavailable, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatusFromCode(tt.code); got != tt.want {
+				t.Errorf("HTTPStatusFromCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
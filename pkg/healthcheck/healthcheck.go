@@ -0,0 +1,33 @@
+// Package healthcheck tracks whether the service's dependencies are
+// currently healthy, shared between the gRPC health service and the
+// HTTP/REST gateway's /readyz endpoint.
+package healthcheck
+
+import "sync/atomic"
+
+// Checker reports readiness as a single atomic flag. It starts ready;
+// callers flip it once a dependency (e.g. the database) is confirmed down.
+type Checker struct {
+	ready int32
+}
+
+// New returns a Checker that starts in the ready state.
+func New() *Checker {
+	c := &Checker{}
+	c.SetReady(true)
+	return c
+}
+
+// SetReady updates the checker's readiness.
+func (c *Checker) SetReady(ready bool) {
+	var v int32
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&c.ready, v)
+}
+
+// Ready reports the checker's current readiness.
+func (c *Checker) Ready() bool {
+	return atomic.LoadInt32(&c.ready) == 1
+}
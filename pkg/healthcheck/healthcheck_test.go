@@ -0,0 +1,24 @@
+package healthcheck
+
+import "testing"
+
+func TestChecker_StartsReady(t *testing.T) {
+	c := New()
+	if !c.Ready() {
+		t.Errorf("Ready() = false, want true for a new Checker")
+	}
+}
+
+func TestChecker_SetReady(t *testing.T) {
+	c := New()
+
+	c.SetReady(false)
+	if c.Ready() {
+		t.Errorf("Ready() = true after SetReady(false), want false")
+	}
+
+	c.SetReady(true)
+	if !c.Ready() {
+		t.Errorf("Ready() = false after SetReady(true), want true")
+	}
+}
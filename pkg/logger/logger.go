@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log is the package-level structured logger used throughout the service.
+// It is initialized by Init and defaults to a no-op logger so packages
+// that log before Init runs (e.g. in tests) don't panic.
+var Log *zap.Logger = zap.NewNop()
+
+// Init builds the global logger at the given zap level ("debug", "info",
+// "warn", "error") and installs it as Log.
+func Init(level string) error {
+	lvl := zapcore.InfoLevel
+	if err := lvl.UnmarshalText([]byte(level)); err != nil && level != "" {
+		return err
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+
+	l, err := cfg.Build()
+	if err != nil {
+		return err
+	}
+
+	Log = l
+	return nil
+}
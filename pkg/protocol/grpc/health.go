@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/healthcheck"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+)
+
+// healthCheckServiceName is the name v1.ToDoService reports its status
+// under in the standard grpc.health.v1.Health service, matching the
+// service's fully-qualified proto name.
+const healthCheckServiceName = "v1.ToDoService"
+
+// healthCheckInterval is how often the repository's backing connection is
+// pinged once registered with the health service.
+const healthCheckInterval = 5 * time.Second
+
+// healthCheckFailureThreshold is the number of consecutive failed pings
+// before v1.ToDoService is reported NOT_SERVING.
+const healthCheckFailureThreshold = 3
+
+// registerHealthServer registers the standard gRPC health checking
+// protocol and, if repo exposes a storage.Pinger, starts pinging it on an
+// interval so Kubernetes liveness/readiness probes reflect real database
+// health rather than just "the process is up".
+func registerHealthServer(ctx context.Context, server *grpc.Server, repo storage.ToDoRepository, checker *healthcheck.Checker) {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(healthCheckServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	pinger, ok := repo.(storage.Pinger)
+	if !ok {
+		return
+	}
+
+	go monitorHealth(ctx, pinger, healthServer, checker)
+}
+
+// monitorHealth pings pinger on an interval, flipping healthServer and
+// checker to NOT_SERVING/not-ready after healthCheckFailureThreshold
+// consecutive failures, until ctx is done.
+func monitorHealth(ctx context.Context, pinger storage.Pinger, healthServer *health.Server, checker *healthcheck.Checker) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	var failures int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, healthCheckInterval)
+			err := pinger.Ping(pingCtx)
+			cancel()
+
+			if err != nil {
+				failures++
+				log.Printf("health check: database ping failed (%d/%d): %v", failures, healthCheckFailureThreshold, err)
+				if failures >= healthCheckFailureThreshold {
+					healthServer.SetServingStatus(healthCheckServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+					checker.SetReady(false)
+				}
+				continue
+			}
+
+			failures = 0
+			healthServer.SetServingStatus(healthCheckServiceName, healthpb.HealthCheckResponse_SERVING)
+			checker.SetReady(true)
+		}
+	}
+}
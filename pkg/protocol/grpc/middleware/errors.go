@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	apperrors "github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/logger"
+)
+
+// ErrorTranslationUnaryServerInterceptor converts the typed domain errors
+// returned by the service layer (pkg/errors) into gRPC status errors. It
+// logs CodeInternal errors together with their captured stack trace and the
+// request's correlation ID before the error crosses the wire.
+func ErrorTranslationUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		return resp, translate(ctx, err)
+	}
+}
+
+// ErrorTranslationStreamServerInterceptor is the streaming counterpart of
+// ErrorTranslationUnaryServerInterceptor.
+func ErrorTranslationStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+		return translate(ss.Context(), err)
+	}
+}
+
+func translate(ctx context.Context, err error) error {
+	var appErr *apperrors.Error
+	if !stderrors.As(err, &appErr) {
+		// Already a status error (e.g. unsupported API version) or an
+		// error type the service layer doesn't produce; pass it through.
+		return err
+	}
+
+	if appErr.Code == apperrors.CodeInternal {
+		fields := []zap.Field{zap.Error(appErr)}
+		if appErr.Stack != nil {
+			fields = append(fields, zap.ByteString("stack", appErr.Stack))
+		}
+		if id, ok := RequestIDFromContext(ctx); ok {
+			fields = append(fields, zap.String("request_id", id))
+		}
+		logger.Log.Error(appErr.Message, fields...)
+	}
+
+	return status.Error(apperrors.GRPCCode(appErr), appErr.Error())
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/logger"
+)
+
+// UnaryServerInterceptor injects a correlation request ID into ctx and logs
+// the request with its duration and resulting gRPC status code.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := requestID(ctx)
+		ctx = withRequestID(ctx, id)
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logger.Log.Info("handled unary call",
+			zap.String("request_id", id),
+			zap.String("method", info.FullMethod),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("code", status.Code(err).String()),
+			zap.Error(err),
+		)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor: it stamps the stream's context with a request ID
+// and logs the call once the stream completes.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestID(ss.Context())
+		wrapped := &serverStreamWithContext{
+			ServerStream: ss,
+			ctx:          withRequestID(ss.Context(), id),
+		}
+		start := time.Now()
+
+		err := handler(srv, wrapped)
+
+		logger.Log.Info("handled stream call",
+			zap.String("request_id", id),
+			zap.String("method", info.FullMethod),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("code", status.Code(err).String()),
+			zap.Error(err),
+		)
+		return err
+	}
+}
+
+// serverStreamWithContext overrides Context() so handlers observe the
+// request-ID-enriched context rather than the raw stream context.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
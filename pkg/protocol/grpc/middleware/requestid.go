@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDKey is the gRPC metadata key carrying the correlation ID for a
+// request, read from incoming metadata and appended to outgoing metadata.
+const requestIDKey = "x-request-id"
+
+type contextKey struct{}
+
+// RequestIDFromContext returns the request ID stashed in ctx by
+// UnaryServerInterceptor/StreamServerInterceptor, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
+
+// requestID extracts an existing request ID from incoming gRPC metadata, or
+// mints a new one if the caller didn't supply one.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return uuid.New().String()
+}
+
+// withRequestID stores id both in the context (for handlers) and in the
+// outgoing metadata (so downstream gRPC calls propagate it).
+func withRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, id)
+	return metadata.AppendToOutgoingContext(ctx, requestIDKey, id)
+}
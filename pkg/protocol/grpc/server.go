@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/healthcheck"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/protocol/grpc/middleware"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+)
+
+// shutdownGracePeriod bounds how long graceful shutdown waits for in-flight
+// RPCs to finish on their own. server.GracefulStop() never cancels a
+// stream's context, so a connected Watch client would otherwise block
+// shutdown forever; past the grace period the server is force-stopped,
+// which does cancel every open stream's context.
+const shutdownGracePeriod = 10 * time.Second
+
+// RunServer runs gRPC service to publish ToDo service. It also registers
+// the standard grpc.health.v1.Health service, reporting v1API's status
+// and, when repo is backed by a live connection, pinging it on an
+// interval to keep checker and the health service in sync with repo's
+// real health. The server shuts down gracefully when ctx is done; callers
+// own signal handling.
+func RunServer(ctx context.Context, v1API v1.ToDoServiceServer, repo storage.ToDoRepository, checker *healthcheck.Checker, port string) error {
+	listen, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	// register service
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(middleware.ChainUnary(
+			middleware.UnaryServerInterceptor(),
+			middleware.ErrorTranslationUnaryServerInterceptor(),
+		)),
+		grpc.StreamInterceptor(middleware.ChainStream(
+			middleware.StreamServerInterceptor(),
+			middleware.ErrorTranslationStreamServerInterceptor(),
+		)),
+	)
+	v1.RegisterToDoServiceServer(server, v1API)
+	registerHealthServer(ctx, server, repo, checker)
+
+	// graceful shutdown, forcing a stop if in-flight RPCs (e.g. a
+	// long-lived Watch stream) don't finish within shutdownGracePeriod
+	go func() {
+		<-ctx.Done()
+		log.Println("shutting down gRPC server...")
+
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(shutdownGracePeriod):
+			log.Println("graceful shutdown timed out, forcing stop")
+			server.Stop()
+		}
+	}()
+
+	log.Println("starting gRPC server on :" + port)
+	return server.Serve(listen)
+}
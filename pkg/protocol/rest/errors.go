@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	apperrors "github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+)
+
+// errorBody is the JSON shape returned for a failed request.
+type errorBody struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// protoErrorHandler overrides grpc-gateway's default error handling to map
+// errors via apperrors.HTTPStatusFromCode instead of its own gRPC-code
+// table, so the HTTP status returned to REST clients is driven by the same
+// domain error mapping as the gRPC transport.
+func protoErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+
+	buf, merr := marshaler.Marshal(errorBody{Error: st.Message(), Code: int(st.Code())})
+	if merr != nil {
+		log.Printf("failed to marshal error response: %v", merr)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"failed to marshal error message"}`))
+		return
+	}
+
+	w.Header().Set("Content-Type", marshaler.ContentType())
+	w.WriteHeader(apperrors.HTTPStatusFromCode(st.Code()))
+	_, _ = w.Write(buf)
+}
@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/logger"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+// withRequestLogging wraps next with a correlation request ID (reused from
+// the incoming header when the caller supplied one) and a structured log
+// line at completion, mirroring the gRPC unary interceptor.
+func withRequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+		r.Header.Set(requestIDHeader, id)
+		w.Header().Set(requestIDHeader, id)
+
+		start := time.Now()
+		next.ServeHTTP(w, r)
+
+		logger.Log.Info("handled HTTP request",
+			zap.String("request_id", id),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Duration("duration", time.Since(start)),
+		)
+	})
+}
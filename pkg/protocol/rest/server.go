@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/healthcheck"
+)
+
+// RunServer runs HTTP/REST gateway in front of the gRPC ToDo service. It
+// also exposes /healthz (liveness: the gateway process is up) and /readyz
+// (readiness: checker's dependencies, e.g. the database, are healthy) for
+// Kubernetes probes.
+func RunServer(ctx context.Context, grpcPort, httpPort string, checker *healthcheck.Checker) error {
+	gwMux := runtime.NewServeMux(runtime.WithProtoErrorHandler(protoErrorHandler))
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+
+	if err := v1.RegisterToDoServiceHandlerFromEndpoint(ctx, gwMux, "localhost:"+grpcPort, opts); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !checker.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("not ready"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ready"))
+	})
+	mux.Handle("/", gwMux)
+
+	srv := &http.Server{
+		Addr:    ":" + httpPort,
+		Handler: withRequestLogging(mux),
+	}
+
+	// graceful shutdown
+	go func() {
+		<-ctx.Done()
+		log.Println("shutting down HTTP/REST gateway...")
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Println("starting HTTP/REST gateway on :" + httpPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"sync"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+)
+
+// subscriberBufferSize bounds each Watch subscriber's event channel; a
+// subscriber that falls behind has new events dropped for it rather than
+// blocking Create/Update/Delete.
+const subscriberBufferSize = 64
+
+// replayBufferSize bounds how many recent events the hub retains in memory
+// so a Watch call can replay events published before it subscribed.
+const replayBufferSize = 256
+
+// subscriber is a single Watch call's view into the hub.
+type subscriber struct {
+	id     int64
+	todoID int64 // 0 means "every todo"
+	events chan *v1.ToDoEvent
+}
+
+// hub is an in-process pub/sub broker for ToDo change events. Create,
+// Update and Delete publish to it; Watch subscribes from the moment of
+// connection and may additionally replay a bounded backlog of recent
+// events for clients resuming from a known revision.
+type hub struct {
+	mu          sync.Mutex
+	nextSubID   int64
+	nextRev     int64
+	subscribers map[int64]*subscriber
+	replay      []*v1.ToDoEvent
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[int64]*subscriber)}
+}
+
+// publish assigns the next revision to evt and fans it out to every
+// subscriber interested in it. A subscriber whose buffer is full has the
+// event dropped for it rather than blocking the publisher.
+func (h *hub) publish(evt *v1.ToDoEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextRev++
+	evt.Rev = h.nextRev
+
+	h.replay = append(h.replay, evt)
+	if len(h.replay) > replayBufferSize {
+		h.replay = h.replay[len(h.replay)-replayBufferSize:]
+	}
+
+	for _, sub := range h.subscribers {
+		if sub.todoID != 0 && sub.todoID != evt.ToDo.GetId() {
+			continue
+		}
+		select {
+		case sub.events <- evt:
+		default:
+			// slow consumer: drop rather than block the publisher
+		}
+	}
+}
+
+// subscribe registers a new subscriber, optionally filtered to a single
+// todoID (0 for all). With startRevision == 0 (proto3's zero value, and the
+// default when a client doesn't set it), the subscription is live-only: no
+// buffered events are replayed. A positive startRevision replays any
+// buffered events with Rev > startRevision before live events start
+// flowing.
+func (h *hub) subscribe(todoID, startRevision int64) *subscriber {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextSubID++
+	sub := &subscriber{
+		id:     h.nextSubID,
+		todoID: todoID,
+		events: make(chan *v1.ToDoEvent, subscriberBufferSize),
+	}
+
+	if startRevision > 0 {
+		for _, evt := range h.replay {
+			if evt.Rev <= startRevision {
+				continue
+			}
+			if sub.todoID != 0 && sub.todoID != evt.ToDo.GetId() {
+				continue
+			}
+			select {
+			case sub.events <- evt:
+			default:
+			}
+		}
+	}
+
+	h.subscribers[sub.id] = sub
+	return sub
+}
+
+// unsubscribe removes sub from the hub; it must be called once the Watch
+// call serving sub returns.
+func (h *hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub.id)
+}
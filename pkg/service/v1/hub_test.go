@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"testing"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+)
+
+func TestHub_SubscribeDefaultIsLiveOnly(t *testing.T) {
+	h := newHub()
+	h.publish(&v1.ToDoEvent{Type: v1.ToDoEvent_CREATED, ToDo: &v1.ToDo{Id: 1}})
+
+	sub := h.subscribe(0, 0)
+	defer h.unsubscribe(sub)
+
+	select {
+	case evt := <-sub.events:
+		t.Fatalf("subscribe(startRevision=0) replayed %+v, want no replay", evt)
+	default:
+	}
+}
+
+func TestHub_SubscribeReplaysAfterStartRevision(t *testing.T) {
+	h := newHub()
+	h.publish(&v1.ToDoEvent{Type: v1.ToDoEvent_CREATED, ToDo: &v1.ToDo{Id: 1}}) // rev 1
+	h.publish(&v1.ToDoEvent{Type: v1.ToDoEvent_UPDATED, ToDo: &v1.ToDo{Id: 1}}) // rev 2
+
+	sub := h.subscribe(0, 1)
+	defer h.unsubscribe(sub)
+
+	evt := <-sub.events
+	if evt.GetType() != v1.ToDoEvent_UPDATED || evt.GetRev() != 2 {
+		t.Errorf("subscribe(startRevision=1) first replayed event = %+v, want the rev-2 UPDATED event", evt)
+	}
+
+	select {
+	case evt := <-sub.events:
+		t.Errorf("subscribe(startRevision=1) replayed an extra event %+v, want only rev 2", evt)
+	default:
+	}
+}
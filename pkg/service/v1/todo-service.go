@@ -2,14 +2,15 @@ package v1
 
 import (
 	"context"
-	"database/sql"
-	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
 )
 
 const (
@@ -19,13 +20,15 @@ const (
 
 // toDoServiceServer is implementation of v1.ToDoServiceServer proto interface
 type toDoServiceServer struct {
-	db *sql.DB
+	repo storage.ToDoRepository
+	hub  *hub
 }
 
-// NewToDoServiceServer creates ToDo service
-func NewToDoServiceServer(db *sql.DB) v1.ToDoServiceServer {
+// NewToDoServiceServer creates ToDo service backed by the given repository
+func NewToDoServiceServer(repo storage.ToDoRepository) v1.ToDoServiceServer {
 	return &toDoServiceServer{
-		db: db,
+		repo: repo,
+		hub:  newHub(),
 	}
 }
 
@@ -40,207 +43,144 @@ func (s *toDoServiceServer) checkAPI(api string) error {
 	return nil
 }
 
-// connect returns SQL database connection from the pool
-func (s *toDoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
-	c, err := s.db.Conn(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to connect to database, %+v", err)
-	}
-	return c, nil
-}
-
 // Create new todo task
 func (s *toDoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (*v1.CreateResponse, error) {
-	// Check if the API version requested by client is supported by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
-	// get SQL connection from pool
-	c, err := s.connect(ctx)
+	id, err := s.repo.Create(ctx, req.ToDo)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
-
-	reminder, err := ptypes.Timestamp(req.ToDo.Reminder)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "reminder field has invalid format, %+v", err)
-	}
 
-	// insert ToDo entiry data
-	res, err := c.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`) VALUES(?, ?, ?)",
-		req.ToDo.GetTitle(), req.ToDo.GetDescription(), reminder)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to insert into ToDo, %+v", err)
-	}
-
-	// get ID of creates ToDo
-	id, err := res.LastInsertId()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrive id for created ToDo, %+v", err)
-	}
+	created := proto.Clone(req.ToDo).(*v1.ToDo)
+	created.Id = id
+	s.hub.publish(&v1.ToDoEvent{Api: apiVersion, Type: v1.ToDoEvent_CREATED, ToDo: created})
 
 	return &v1.CreateResponse{Api: apiVersion, Id: id}, nil
 }
 
 // Read todo task
 func (s *toDoServiceServer) Read(ctx context.Context, req *v1.ReadRequest) (*v1.ReadResponse, error) {
-	// check if the API version requested by client is supported by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
-	// get SQL connection from pool
-	c, err := s.connect(ctx)
+	td, err := s.repo.Read(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
 
-	// request todo by ID
-	rows, err := s.db.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo WHERE `ID`=?", req.Id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select from ToDo, %+v", err)
-	}
-	defer rows.Close()
-
-	if !rows.Next() {
-		if err := rows.Err(); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to retrieve data from ToDo, %+v", err)
-		}
-		return nil, status.Errorf(codes.NotFound, "ToDo with ID='%d' is not found", req.Id)
-	}
-
-	// get ToDo data
-	var td v1.ToDo
-	var reminder time.Time
-
-	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve field value from ToDo row, %+v", err)
-	}
-	td.Reminder, err = ptypes.TimestampProto(reminder)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "reminder field has invalid format, %+v", err)
-	}
-
-	if rows.Next() {
-		return nil, status.Errorf(codes.Internal, "found multiple ToDo rows with ID='%d'", req.Id)
-	}
-
-	return &v1.ReadResponse{Api: apiVersion, ToDo: &td}, nil
+	return &v1.ReadResponse{Api: apiVersion, ToDo: td}, nil
 }
 
 // Update todo task
 func (s *toDoServiceServer) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateResponse, error) {
-	// check if the API version request by client is support by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
-	// get SQL connection from pool
-	c, err := s.connect(ctx)
+	updated, err := s.repo.Update(ctx, req.ToDo)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
-
-	reminder, err := ptypes.Timestamp(req.ToDo.GetReminder())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "reminder filed has invalid format, %+v", err)
+	if updated == 0 {
+		return nil, errors.NotFound("ToDo with ID='%d' is not found", req.ToDo.GetId())
 	}
 
-	// update ToDo
-	res, err := c.ExecContext(ctx, "UPDATE ToDo SET `Title`=?, `Description`=?, `Reminder`=? WHERE `ID`=?",
-		req.ToDo.GetTitle(), req.ToDo.GetDescription(), reminder, req.ToDo.GetId())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update ToDo, %+v", err)
-	}
-
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve rows affected, %+v", err)
-	}
-
-	if rows == 0 {
-		return nil, status.Errorf(codes.NotFound, "ToDo with ID='%d' is not found", req.ToDo.GetId())
-	}
+	s.hub.publish(&v1.ToDoEvent{Api: apiVersion, Type: v1.ToDoEvent_UPDATED, ToDo: proto.Clone(req.ToDo).(*v1.ToDo)})
 
-	return &v1.UpdateResponse{Api: apiVersion, Updated: rows}, nil
+	return &v1.UpdateResponse{Api: apiVersion, Updated: updated}, nil
 }
 
 // Delete todo task
 func (s *toDoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (*v1.DeleteResponse, error) {
-	// check if the API version requested by client is support by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
-	// get SQL connection from pool
-	c, err := s.connect(ctx)
+	deleted, err := s.repo.Delete(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
-
-	// delete ToDo
-	res, err := c.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID`=?", req.Id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete ToDo, %+v", err)
-	}
-
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve rows affected, %+v", err)
+	if deleted == 0 {
+		return nil, errors.NotFound("ToDo with ID='%d' is not found", req.Id)
 	}
 
-	if rows == 0 {
-		return nil, status.Errorf(codes.NotFound, "ToDo with ID='%d' is not found", req.Id)
-	}
+	s.hub.publish(&v1.ToDoEvent{Api: apiVersion, Type: v1.ToDoEvent_DELETED, ToDo: &v1.ToDo{Id: req.Id}})
 
-	return &v1.DeleteResponse{Api: apiVersion, Deleted: rows}, nil
+	return &v1.DeleteResponse{Api: apiVersion, Deleted: deleted}, nil
 }
 
 // Read all todo taks
 func (s *toDoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest) (*v1.ReadAllResponse, error) {
-	// check if the API version requested by client is supported by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
-	// get SQL connection from pool
-	c, err := s.connect(ctx)
+	opts, err := listOptionsFromRequest(req)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
 
-	// get ToDo list
-	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo")
+	list, nextPageToken, err := s.repo.List(ctx, opts)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to select from ToDo, %+v", err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	var reminder time.Time
-	list := []*v1.ToDo{}
+	return &v1.ReadAllResponse{Api: apiVersion, ToDos: list, NextPageToken: nextPageToken}, nil
+}
 
-	for rows.Next() {
-		td := new(v1.ToDo)
-		if err := rows.Scan(td.Id, td.Title, td.Description, &reminder); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to retrieve field value from rows, %+v", err)
-		}
+// listOptionsFromRequest converts a ReadAllRequest's pagination, filter and
+// sort fields into storage.ListOptions.
+func listOptionsFromRequest(req *v1.ReadAllRequest) (storage.ListOptions, error) {
+	opts := storage.ListOptions{
+		PageSize:      req.GetPageSize(),
+		PageToken:     req.GetPageToken(),
+		OrderBy:       req.GetOrderBy(),
+		TitleContains: req.GetTitleContains(),
+		OverdueOnly:   req.GetOverdueOnly(),
+	}
 
-		td.Reminder, err = ptypes.TimestampProto(reminder)
+	if ts := req.GetReminderAfter(); ts != nil {
+		t, err := ptypes.Timestamp(ts)
+		if err != nil {
+			return opts, errors.Validation("reminder_after has invalid format, %+v", err)
+		}
+		opts.ReminderAfter = &t
+	}
+	if ts := req.GetReminderBefore(); ts != nil {
+		t, err := ptypes.Timestamp(ts)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "reminder field has invalid format, %+v", err)
+			return opts, errors.Validation("reminder_before has invalid format, %+v", err)
 		}
-		list = append(list, td)
+		opts.ReminderBefore = &t
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve data from ToDo rows, %+v", err)
+	return opts, nil
+}
+
+// Watch streams Created/Updated/Deleted events for to-do tasks, optionally
+// filtered to a single ID and optionally replaying events published after
+// req.StartRevision.
+func (s *toDoServiceServer) Watch(req *v1.WatchRequest, stream v1.ToDoService_WatchServer) error {
+	if err := s.checkAPI(req.Api); err != nil {
+		return err
 	}
 
-	return &v1.ReadAllResponse{Api: apiVersion, ToDos: list}, nil
+	sub := s.hub.subscribe(req.GetId(), req.GetStartRevision())
+	defer s.hub.unsubscribe(sub)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case evt := <-sub.events:
+			if err := stream.Send(evt); err != nil {
+				return err
+			}
+		}
+	}
 }
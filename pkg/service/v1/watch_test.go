@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/internal/testsupport"
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage/memory"
+)
+
+// startTestServer spins up the ToDo service over an in-memory bufconn
+// listener, returning a connected client and a func to tear everything down.
+func startTestServer(t *testing.T) (v1.ToDoServiceClient, func()) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	v1.RegisterToDoServiceServer(server, NewToDoServiceServer(memory.NewRepository()))
+	go func() { _ = server.Serve(lis) }()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+
+	return v1.NewToDoServiceClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestWatch_ReceivesCreateEvent(t *testing.T) {
+	client, closeFn := startTestServer(t)
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &v1.WatchRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if _, err := client.Create(ctx, &v1.CreateRequest{ToDo: &v1.ToDo{Title: "buy milk", Reminder: testsupport.Reminder()}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	evt, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if evt.GetType() != v1.ToDoEvent_CREATED || evt.GetToDo().GetTitle() != "buy milk" {
+		t.Errorf("Recv() = %+v, want a CREATED event for %q", evt, "buy milk")
+	}
+}
+
+func TestWatch_FiltersByID(t *testing.T) {
+	client, closeFn := startTestServer(t)
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := client.Create(ctx, &v1.CreateRequest{ToDo: &v1.ToDo{Title: "watched", Reminder: testsupport.Reminder()}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	stream, err := client.Watch(ctx, &v1.WatchRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if _, err := client.Create(ctx, &v1.CreateRequest{ToDo: &v1.ToDo{Title: "ignored", Reminder: testsupport.Reminder()}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := client.Update(ctx, &v1.UpdateRequest{ToDo: &v1.ToDo{Id: created.GetId(), Title: "watched v2", Reminder: testsupport.Reminder()}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	evt, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if evt.GetType() != v1.ToDoEvent_UPDATED || evt.GetToDo().GetId() != created.GetId() {
+		t.Errorf("Recv() = %+v, want the UPDATED event for id=%d (not the unrelated Create)", evt, created.GetId())
+	}
+}
+
+func TestWatch_StopsOnContextCancel(t *testing.T) {
+	client, closeFn := startTestServer(t)
+	defer closeFn()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &v1.WatchRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	cancel()
+
+	if _, err := stream.Recv(); err == nil {
+		t.Errorf("Recv() after context cancel = nil error, want non-nil")
+	}
+}
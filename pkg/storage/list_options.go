@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+)
+
+// DefaultPageSize is used when ListOptions.PageSize is zero.
+const DefaultPageSize = 50
+
+// MaxPageSize caps ListOptions.PageSize regardless of what the caller asked for.
+const MaxPageSize = 500
+
+// ListOptions controls pagination, filtering and sorting for
+// ToDoRepository.List.
+type ListOptions struct {
+	// PageSize is the maximum number of to-do tasks to return. Zero means
+	// DefaultPageSize.
+	PageSize int32
+	// PageToken is the opaque cursor returned as NextPageToken by a
+	// previous List call; empty starts from the first page.
+	PageToken string
+	// OrderBy is "<field> [asc|desc]", e.g. "reminder desc". Supported
+	// fields are "id" (default) and "reminder".
+	OrderBy string
+
+	// TitleContains filters to tasks whose title contains this substring.
+	TitleContains string
+	// ReminderAfter, if set, filters to tasks reminding at or after this time.
+	ReminderAfter *time.Time
+	// ReminderBefore, if set, filters to tasks reminding at or before this time.
+	ReminderBefore *time.Time
+	// OverdueOnly filters to tasks whose reminder has already passed.
+	OverdueOnly bool
+}
+
+// SortField parses OrderBy into a validated field name and whether the
+// order is descending, defaulting to "id asc". It returns a Validation
+// error for an unsupported field or direction.
+func (o ListOptions) SortField() (string, bool, error) {
+	if o.OrderBy == "" {
+		return "id", false, nil
+	}
+
+	field := o.OrderBy
+	desc := false
+	if i := lastSpace(field); i >= 0 {
+		dir := field[i+1:]
+		field = field[:i]
+		switch dir {
+		case "asc":
+			desc = false
+		case "desc":
+			desc = true
+		default:
+			return "", false, errors.Validation("order_by has invalid direction: %q", dir)
+		}
+	}
+
+	switch field {
+	case "id", "reminder":
+		return field, desc, nil
+	default:
+		return "", false, errors.Validation("order_by has unsupported field: %q", field)
+	}
+}
+
+func lastSpace(s string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// Limit returns the effective page size, applying DefaultPageSize and
+// MaxPageSize.
+func (o ListOptions) Limit() int32 {
+	switch {
+	case o.PageSize <= 0:
+		return DefaultPageSize
+	case o.PageSize > MaxPageSize:
+		return MaxPageSize
+	default:
+		return o.PageSize
+	}
+}
+
+// cursor is the decoded form of an opaque page token: the sort column's
+// value and ID of the last row on the previous page, used to resume with a
+// keyset WHERE clause instead of OFFSET/LIMIT.
+type cursor struct {
+	LastID    int64      `json:"i"`
+	LastValue *time.Time `json:"v,omitempty"`
+}
+
+// EncodeCursor returns the opaque page token for the last row returned,
+// identified by its ID and (if sorting by a non-id field) that field's value.
+func EncodeCursor(lastID int64, lastValue *time.Time) string {
+	b, _ := json.Marshal(cursor{LastID: lastID, LastValue: lastValue})
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a page token produced by EncodeCursor. An empty token
+// decodes to the zero cursor, meaning "start from the first page".
+func DecodeCursor(token string) (lastID int64, lastValue *time.Time, err error) {
+	if token == "" {
+		return 0, nil, nil
+	}
+	b, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, nil, errors.Validation("page_token is invalid")
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return 0, nil, errors.Validation("page_token is invalid")
+	}
+	return c.LastID, c.LastValue, nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListOptions_SortField(t *testing.T) {
+	tests := []struct {
+		name      string
+		orderBy   string
+		wantField string
+		wantDesc  bool
+		wantErr   bool
+	}{
+		{"default", "", "id", false, false},
+		{"id asc", "id asc", "id", false, false},
+		{"reminder desc", "reminder desc", "reminder", true, false},
+		{"unsupported field", "title asc", "", false, true},
+		{"unsupported direction", "id sideways", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, desc, err := ListOptions{OrderBy: tt.orderBy}.SortField()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SortField() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SortField() error = %v", err)
+			}
+			if field != tt.wantField || desc != tt.wantDesc {
+				t.Errorf("SortField() = (%q, %v), want (%q, %v)", field, desc, tt.wantField, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	token := EncodeCursor(42, &now)
+
+	id, value, err := DecodeCursor(token)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if id != 42 {
+		t.Errorf("DecodeCursor() id = %d, want 42", id)
+	}
+	if value == nil || !value.Equal(now) {
+		t.Errorf("DecodeCursor() value = %v, want %v", value, now)
+	}
+}
+
+func TestDecodeCursor_Empty(t *testing.T) {
+	id, value, err := DecodeCursor("")
+	if err != nil || id != 0 || value != nil {
+		t.Errorf("DecodeCursor(\"\") = (%d, %v, %v), want (0, nil, nil)", id, value, err)
+	}
+}
@@ -0,0 +1,231 @@
+// Package memory is an in-memory storage.ToDoRepository, suitable for tests
+// and local development without a real database.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+)
+
+// Repository is an in-memory storage.ToDoRepository.
+type Repository struct {
+	mu     sync.Mutex
+	nextID int64
+	todos  map[int64]*v1.ToDo
+}
+
+// NewRepository returns an empty in-memory repository.
+func NewRepository() *Repository {
+	return &Repository{todos: make(map[int64]*v1.ToDo)}
+}
+
+// Create new todo task
+func (r *Repository) Create(ctx context.Context, td *v1.ToDo) (int64, error) {
+	if _, err := ptypes.Timestamp(td.GetReminder()); err != nil {
+		return 0, errors.Validation("reminder field has invalid format, %+v", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	clone := proto.Clone(td).(*v1.ToDo)
+	clone.Id = r.nextID
+	r.todos[r.nextID] = clone
+	return r.nextID, nil
+}
+
+// Read todo task
+func (r *Repository) Read(ctx context.Context, id int64) (*v1.ToDo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	td, ok := r.todos[id]
+	if !ok {
+		return nil, errors.NotFound("ToDo with ID='%d' is not found", id)
+	}
+	return proto.Clone(td).(*v1.ToDo), nil
+}
+
+// Update todo task
+func (r *Repository) Update(ctx context.Context, td *v1.ToDo) (int64, error) {
+	if _, err := ptypes.Timestamp(td.GetReminder()); err != nil {
+		return 0, errors.Validation("reminder filed has invalid format, %+v", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.todos[td.GetId()]; !ok {
+		return 0, nil
+	}
+	r.todos[td.GetId()] = proto.Clone(td).(*v1.ToDo)
+	return 1, nil
+}
+
+// Delete todo task
+func (r *Repository) Delete(ctx context.Context, id int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.todos[id]; !ok {
+		return 0, nil
+	}
+	delete(r.todos, id)
+	return 1, nil
+}
+
+// List returns a page of to-do tasks matching opts
+func (r *Repository) List(ctx context.Context, opts storage.ListOptions) ([]*v1.ToDo, string, error) {
+	field, desc, err := opts.SortField()
+	if err != nil {
+		return nil, "", err
+	}
+	lastID, lastValue, err := storage.DecodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.Lock()
+	all := make([]*v1.ToDo, 0, len(r.todos))
+	for _, td := range r.todos {
+		all = append(all, proto.Clone(td).(*v1.ToDo))
+	}
+	r.mu.Unlock()
+
+	matching, err := filterToDos(all, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	sortToDos(matching, field, desc)
+
+	start := 0
+	if opts.PageToken != "" {
+		start = indexAfterCursor(matching, field, desc, lastID, lastValue)
+	}
+
+	limit := int(opts.Limit())
+	end := start + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	if start > len(matching) {
+		start = len(matching)
+	}
+	page := matching[start:end]
+
+	var nextToken string
+	if end < len(matching) {
+		last := page[len(page)-1]
+		nextToken = storage.EncodeCursor(last.GetId(), sortValue(last, field))
+	}
+
+	return page, nextToken, nil
+}
+
+func filterToDos(todos []*v1.ToDo, opts storage.ListOptions) ([]*v1.ToDo, error) {
+	out := make([]*v1.ToDo, 0, len(todos))
+	for _, td := range todos {
+		if opts.TitleContains != "" && !strings.Contains(td.GetTitle(), opts.TitleContains) {
+			continue
+		}
+
+		if opts.ReminderAfter != nil || opts.ReminderBefore != nil || opts.OverdueOnly {
+			reminder, err := ptypes.Timestamp(td.GetReminder())
+			if err != nil {
+				return nil, errors.Internal(err, "reminder field has invalid format")
+			}
+			if opts.ReminderAfter != nil && reminder.Before(*opts.ReminderAfter) {
+				continue
+			}
+			if opts.ReminderBefore != nil && reminder.After(*opts.ReminderBefore) {
+				continue
+			}
+			if opts.OverdueOnly && !reminder.Before(time.Now()) {
+				continue
+			}
+		}
+
+		out = append(out, td)
+	}
+	return out, nil
+}
+
+func sortToDos(todos []*v1.ToDo, field string, desc bool) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		less := lessByField(todos[i], todos[j], field)
+		if desc {
+			return !less && !equalByField(todos[i], todos[j], field)
+		}
+		return less
+	})
+}
+
+func lessByField(a, b *v1.ToDo, field string) bool {
+	if field == "reminder" {
+		ta, _ := ptypes.Timestamp(a.GetReminder())
+		tb, _ := ptypes.Timestamp(b.GetReminder())
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		return a.GetId() < b.GetId()
+	}
+	return a.GetId() < b.GetId()
+}
+
+func equalByField(a, b *v1.ToDo, field string) bool {
+	if field == "reminder" {
+		ta, _ := ptypes.Timestamp(a.GetReminder())
+		tb, _ := ptypes.Timestamp(b.GetReminder())
+		return ta.Equal(tb) && a.GetId() == b.GetId()
+	}
+	return a.GetId() == b.GetId()
+}
+
+// indexAfterCursor returns the index of the first element strictly after
+// the (lastValue, lastID) position recorded in the page token.
+func indexAfterCursor(todos []*v1.ToDo, field string, desc bool, lastID int64, lastValue *time.Time) int {
+	for i, td := range todos {
+		if pastCursor(td, field, desc, lastID, lastValue) {
+			return i
+		}
+	}
+	return len(todos)
+}
+
+func pastCursor(td *v1.ToDo, field string, desc bool, lastID int64, lastValue *time.Time) bool {
+	if field == "reminder" && lastValue != nil {
+		reminder, _ := ptypes.Timestamp(td.GetReminder())
+		if !reminder.Equal(*lastValue) {
+			if desc {
+				return reminder.Before(*lastValue)
+			}
+			return reminder.After(*lastValue)
+		}
+	}
+	if desc {
+		return td.GetId() < lastID
+	}
+	return td.GetId() > lastID
+}
+
+func sortValue(td *v1.ToDo, field string) *time.Time {
+	if field != "reminder" {
+		return nil
+	}
+	reminder, err := ptypes.Timestamp(td.GetReminder())
+	if err != nil {
+		return nil
+	}
+	return &reminder
+}
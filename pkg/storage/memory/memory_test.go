@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thanhbinhdoan1993/go-grpc-example/internal/testsupport"
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+)
+
+func TestRepository_CreateRead(t *testing.T) {
+	r := NewRepository()
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &v1.ToDo{Title: "buy milk", Reminder: testsupport.Reminder()})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	td, err := r.Read(ctx, id)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if td.GetTitle() != "buy milk" {
+		t.Errorf("Read().Title = %q, want %q", td.GetTitle(), "buy milk")
+	}
+}
+
+func TestRepository_ReadNotFound(t *testing.T) {
+	r := NewRepository()
+
+	_, err := r.Read(context.Background(), 42)
+	if errors.GRPCCode(err).String() != "NotFound" {
+		t.Errorf("Read() on missing ID: GRPCCode(err) = %v, want NotFound", errors.GRPCCode(err))
+	}
+}
+
+func TestRepository_UpdateDelete(t *testing.T) {
+	r := NewRepository()
+	ctx := context.Background()
+
+	id, err := r.Create(ctx, &v1.ToDo{Title: "buy milk", Reminder: testsupport.Reminder()})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	updated, err := r.Update(ctx, &v1.ToDo{Id: id, Title: "buy bread", Reminder: testsupport.Reminder()})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated != 1 {
+		t.Errorf("Update() rows = %d, want 1", updated)
+	}
+
+	deleted, err := r.Delete(ctx, id)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Delete() rows = %d, want 1", deleted)
+	}
+
+	if deleted, err := r.Delete(ctx, id); err != nil || deleted != 0 {
+		t.Errorf("Delete() of already-deleted ID = (%d, %v), want (0, nil)", deleted, err)
+	}
+}
+
+func TestRepository_List(t *testing.T) {
+	r := NewRepository()
+	ctx := context.Background()
+
+	if _, err := r.Create(ctx, &v1.ToDo{Title: "one", Reminder: testsupport.Reminder()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := r.Create(ctx, &v1.ToDo{Title: "two", Reminder: testsupport.Reminder()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	list, next, err := r.List(ctx, storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("List() returned %d todos, want 2", len(list))
+	}
+	if next != "" {
+		t.Errorf("List() next_page_token = %q, want empty since everything fit on one page", next)
+	}
+}
+
+func TestRepository_ListFiltersByTitle(t *testing.T) {
+	r := NewRepository()
+	ctx := context.Background()
+
+	if _, err := r.Create(ctx, &v1.ToDo{Title: "buy milk", Reminder: testsupport.Reminder()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := r.Create(ctx, &v1.ToDo{Title: "walk the dog", Reminder: testsupport.Reminder()}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	list, _, err := r.List(ctx, storage.ListOptions{TitleContains: "milk"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 1 || list[0].GetTitle() != "buy milk" {
+		t.Errorf("List(TitleContains=milk) = %v, want a single \"buy milk\" entry", list)
+	}
+}
+
+func TestRepository_ListPagination(t *testing.T) {
+	r := NewRepository()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := r.Create(ctx, &v1.ToDo{Title: "task", Reminder: testsupport.Reminder()}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	page1, next1, err := r.List(ctx, storage.ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("List() page 1 error = %v", err)
+	}
+	if len(page1) != 2 || next1 == "" {
+		t.Fatalf("List() page 1 = (%d items, next=%q), want (2 items, non-empty next)", len(page1), next1)
+	}
+
+	page2, next2, err := r.List(ctx, storage.ListOptions{PageSize: 2, PageToken: next1})
+	if err != nil {
+		t.Fatalf("List() page 2 error = %v", err)
+	}
+	if len(page2) != 2 || next2 == "" {
+		t.Fatalf("List() page 2 = (%d items, next=%q), want (2 items, non-empty next)", len(page2), next2)
+	}
+	if page1[0].GetId() == page2[0].GetId() {
+		t.Errorf("List() page 2 repeats page 1's first item (id=%d)", page1[0].GetId())
+	}
+
+	page3, next3, err := r.List(ctx, storage.ListOptions{PageSize: 2, PageToken: next2})
+	if err != nil {
+		t.Fatalf("List() page 3 error = %v", err)
+	}
+	if len(page3) != 1 || next3 != "" {
+		t.Errorf("List() page 3 = (%d items, next=%q), want (1 item, empty next)", len(page3), next3)
+	}
+}
@@ -0,0 +1,262 @@
+// Package mysql is the MySQL-backed storage.ToDoRepository implementation.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/errors"
+	"github.com/thanhbinhdoan1993/go-grpc-example/pkg/storage"
+)
+
+// Repository is a MySQL-backed storage.ToDoRepository.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository wraps an open MySQL connection pool as a storage.ToDoRepository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// connect returns SQL database connection from the pool
+func (r *Repository) connect(ctx context.Context) (*sql.Conn, error) {
+	c, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Internal(err, "failed to connect to database")
+	}
+	return c, nil
+}
+
+// Ping reports whether the underlying database connection is healthy; it
+// satisfies storage.Pinger so it can be health-checked independently of
+// serving traffic.
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// Create new todo task
+func (r *Repository) Create(ctx context.Context, td *v1.ToDo) (int64, error) {
+	c, err := r.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	reminder, err := ptypes.Timestamp(td.GetReminder())
+	if err != nil {
+		return 0, errors.Validation("reminder field has invalid format, %+v", err)
+	}
+
+	// insert ToDo entiry data
+	res, err := c.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`) VALUES(?, ?, ?)",
+		td.GetTitle(), td.GetDescription(), reminder)
+	if err != nil {
+		return 0, errors.Internal(err, "failed to insert into ToDo")
+	}
+
+	// get ID of created ToDo
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, errors.Internal(err, "failed to retrive id for created ToDo")
+	}
+	return id, nil
+}
+
+// Read todo task
+func (r *Repository) Read(ctx context.Context, id int64) (*v1.ToDo, error) {
+	// request todo by ID
+	rows, err := r.db.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo WHERE `ID`=?", id)
+	if err != nil {
+		return nil, errors.Internal(err, "failed to select from ToDo")
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, errors.Internal(err, "failed to retrieve data from ToDo")
+		}
+		return nil, errors.NotFound("ToDo with ID='%d' is not found", id)
+	}
+
+	td, err := scanToDo(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows.Next() {
+		return nil, errors.Internal(nil, "found multiple ToDo rows with ID='%d'", id)
+	}
+	return td, nil
+}
+
+// Update todo task
+func (r *Repository) Update(ctx context.Context, td *v1.ToDo) (int64, error) {
+	c, err := r.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	reminder, err := ptypes.Timestamp(td.GetReminder())
+	if err != nil {
+		return 0, errors.Validation("reminder filed has invalid format, %+v", err)
+	}
+
+	res, err := c.ExecContext(ctx, "UPDATE ToDo SET `Title`=?, `Description`=?, `Reminder`=? WHERE `ID`=?",
+		td.GetTitle(), td.GetDescription(), reminder, td.GetId())
+	if err != nil {
+		return 0, errors.Internal(err, "failed to update ToDo")
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Internal(err, "failed to retrieve rows affected")
+	}
+	return rows, nil
+}
+
+// Delete todo task
+func (r *Repository) Delete(ctx context.Context, id int64) (int64, error) {
+	c, err := r.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	res, err := c.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID`=?", id)
+	if err != nil {
+		return 0, errors.Internal(err, "failed to delete ToDo")
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Internal(err, "failed to retrieve rows affected")
+	}
+	return rows, nil
+}
+
+// List returns a page of to-do tasks matching opts, using keyset
+// pagination (a WHERE clause anchored on the last row of the previous
+// page) rather than OFFSET/LIMIT so large tables stay fast to page through.
+func (r *Repository) List(ctx context.Context, opts storage.ListOptions) ([]*v1.ToDo, string, error) {
+	field, desc, err := opts.SortField()
+	if err != nil {
+		return nil, "", err
+	}
+	lastID, lastValue, err := storage.DecodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := r.connect(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer c.Close()
+
+	column := "`ID`"
+	op, order := ">", "ASC"
+	if field == "reminder" {
+		column = "`Reminder`"
+	}
+	if desc {
+		op, order = "<", "DESC"
+	}
+
+	query := "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo WHERE 1=1"
+	var args []interface{}
+
+	if opts.TitleContains != "" {
+		query += " AND `Title` LIKE ?"
+		args = append(args, "%"+opts.TitleContains+"%")
+	}
+	if opts.ReminderAfter != nil {
+		query += " AND `Reminder` >= ?"
+		args = append(args, *opts.ReminderAfter)
+	}
+	if opts.ReminderBefore != nil {
+		query += " AND `Reminder` <= ?"
+		args = append(args, *opts.ReminderBefore)
+	}
+	if opts.OverdueOnly {
+		query += " AND `Reminder` < ?"
+		args = append(args, time.Now())
+	}
+	if opts.PageToken != "" {
+		if field == "reminder" && lastValue != nil {
+			query += fmt.Sprintf(" AND (%s %s ? OR (%s = ? AND `ID` %s ?))", column, op, column, op)
+			args = append(args, *lastValue, *lastValue, lastID)
+		} else {
+			query += fmt.Sprintf(" AND `ID` %s ?", op)
+			args = append(args, lastID)
+		}
+	}
+
+	limit := opts.Limit()
+	query += fmt.Sprintf(" ORDER BY %s %s, `ID` %s LIMIT ?", column, order, order)
+	args = append(args, limit+1) // fetch one extra row to know whether there's a next page
+
+	rows, err := c.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", errors.Internal(err, "failed to select from ToDo")
+	}
+	defer rows.Close()
+
+	list := []*v1.ToDo{}
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		list = append(list, td)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", errors.Internal(err, "failed to retrieve data from ToDo rows")
+	}
+
+	var nextToken string
+	if int32(len(list)) > limit {
+		last := list[limit-1]
+		list = list[:limit]
+		nextToken = storage.EncodeCursor(last.GetId(), sortValue(last, field))
+	}
+
+	return list, nextToken, nil
+}
+
+// sortValue extracts the value of field from td for embedding in a page
+// token cursor; only "reminder" carries a non-ID sort value.
+func sortValue(td *v1.ToDo, field string) *time.Time {
+	if field != "reminder" {
+		return nil
+	}
+	reminder, err := ptypes.Timestamp(td.GetReminder())
+	if err != nil {
+		return nil
+	}
+	return &reminder
+}
+
+// scanToDo scans a single ToDo row, converting its Reminder column to a
+// protobuf Timestamp.
+func scanToDo(rows *sql.Rows) (*v1.ToDo, error) {
+	var td v1.ToDo
+	var reminder time.Time
+
+	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
+		return nil, errors.Internal(err, "failed to retrieve field value from ToDo row")
+	}
+
+	ts, err := ptypes.TimestampProto(reminder)
+	if err != nil {
+		return nil, errors.Internal(err, "reminder field has invalid format")
+	}
+	td.Reminder = ts
+	return &td, nil
+}
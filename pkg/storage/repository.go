@@ -0,0 +1,40 @@
+// Package storage defines the persistence boundary for to-do tasks so the
+// service layer can depend on an interface rather than a concrete database
+// driver.
+package storage
+
+import (
+	"context"
+
+	v1 "github.com/thanhbinhdoan1993/go-grpc-example/pkg/api/v1"
+)
+
+// ToDoRepository is implemented by each supported datastore backend (MySQL,
+// PostgreSQL, an in-memory store for tests, ...). pkg/service/v1 depends
+// only on this interface so the backing datastore can be swapped via
+// config without touching service code.
+type ToDoRepository interface {
+	// Create persists a new to-do task and returns its generated ID.
+	Create(ctx context.Context, td *v1.ToDo) (int64, error)
+	// Read returns the to-do task with the given ID.
+	Read(ctx context.Context, id int64) (*v1.ToDo, error)
+	// Update overwrites the to-do task matching td.Id and returns the
+	// number of rows updated (0 if no task has that ID).
+	Update(ctx context.Context, td *v1.ToDo) (int64, error)
+	// Delete removes the to-do task with the given ID and returns the
+	// number of rows deleted (0 if no task has that ID).
+	Delete(ctx context.Context, id int64) (int64, error)
+	// List returns a page of to-do tasks matching opts, along with the
+	// page token to pass as opts.PageToken to fetch the next page (empty
+	// when there are no more results).
+	List(ctx context.Context, opts ListOptions) ([]*v1.ToDo, string, error)
+}
+
+// Pinger is implemented by repositories backed by a live connection (the
+// SQL-backed ones) that can be health-checked independently of serving
+// traffic. Repositories with no external dependency, such as the in-memory
+// store, don't implement it.
+type Pinger interface {
+	// Ping reports whether the repository's backing connection is healthy.
+	Ping(ctx context.Context) error
+}